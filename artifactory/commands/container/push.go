@@ -3,7 +3,10 @@ package container
 import (
 	"fmt"
 	"path"
+	"strings"
 
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/pushutil"
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	containerutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
@@ -18,9 +21,19 @@ import (
 
 type PushCommand struct {
 	ContainerCommand
-	threads         int
-	detailedSummary bool
-	result          *commandsutils.Result
+	threads                int
+	detailedSummary        bool
+	result                 *commandsutils.Result
+	signImage              bool
+	signOptional           bool
+	signingKeyRef          string
+	signatureRepo          string
+	shortNameMode          pushutil.ShortNameMode
+	shortNameAliases       map[string]string
+	platforms              []string
+	skipPrechecks          bool
+	preChecks              []pushutil.ContainerPushPreCheck
+	reuseExistingBuildInfo bool
 }
 
 func NewPushCommand(containerManagerType containerutils.ContainerManagerType) *PushCommand {
@@ -58,6 +71,108 @@ func (pc *PushCommand) IsValidateSha() bool {
 	return pc.ContainerCommandBase.IsValidateSha()
 }
 
+// SetSignImage enables post-push signing of the pushed manifest(s) using the configured signer and key reference.
+func (pc *PushCommand) SetSignImage(signImage bool) *PushCommand {
+	pc.signImage = signImage
+	return pc
+}
+
+func (pc *PushCommand) IsSignImage() bool {
+	return pc.signImage
+}
+
+// SetSignOptional downgrades signing failures from a hard error to a logged warning.
+func (pc *PushCommand) SetSignOptional(signOptional bool) *PushCommand {
+	pc.signOptional = signOptional
+	return pc
+}
+
+func (pc *PushCommand) IsSignOptional() bool {
+	return pc.signOptional
+}
+
+// SetSigningKeyRef accepts a file path, an env://VAR reference or an Artifactory-stored key path.
+func (pc *PushCommand) SetSigningKeyRef(signingKeyRef string) *PushCommand {
+	pc.signingKeyRef = signingKeyRef
+	return pc
+}
+
+func (pc *PushCommand) SigningKeyRef() string {
+	return pc.signingKeyRef
+}
+
+// SetSignatureRepo overrides the repository signatures are uploaded to. Defaults to the image's own repo.
+func (pc *PushCommand) SetSignatureRepo(signatureRepo string) *PushCommand {
+	pc.signatureRepo = signatureRepo
+	return pc
+}
+
+func (pc *PushCommand) SignatureRepo() string {
+	return pc.signatureRepo
+}
+
+// SetShortNameMode overrides the server-configured short-name resolution mode for this command invocation.
+func (pc *PushCommand) SetShortNameMode(mode pushutil.ShortNameMode) *PushCommand {
+	pc.shortNameMode = mode
+	return pc
+}
+
+func (pc *PushCommand) ShortNameMode() pushutil.ShortNameMode {
+	return pc.shortNameMode
+}
+
+// SetShortNameAliases overrides the aliases loaded from ~/.jfrog/short-name-aliases.conf for this command invocation.
+func (pc *PushCommand) SetShortNameAliases(aliases map[string]string) *PushCommand {
+	pc.shortNameAliases = aliases
+	return pc
+}
+
+func (pc *PushCommand) ShortNameAliases() map[string]string {
+	return pc.shortNameAliases
+}
+
+// SetPlatforms restricts which per-platform manifests of a pushed OCI index / manifest list are recorded
+// in build-info. When empty, every child manifest of the index is recorded.
+func (pc *PushCommand) SetPlatforms(platforms []string) *PushCommand {
+	pc.platforms = platforms
+	return pc
+}
+
+func (pc *PushCommand) Platforms() []string {
+	return pc.platforms
+}
+
+// SetSkipPrechecks bypasses the pre-push validation runner for backward compatibility.
+func (pc *PushCommand) SetSkipPrechecks(skipPrechecks bool) *PushCommand {
+	pc.skipPrechecks = skipPrechecks
+	return pc
+}
+
+func (pc *PushCommand) IsSkipPrechecks() bool {
+	return pc.skipPrechecks
+}
+
+// AddPreCheck registers an additional check to run alongside the built-in ones before the native push is invoked.
+func (pc *PushCommand) AddPreCheck(check pushutil.ContainerPushPreCheck) *PushCommand {
+	pc.preChecks = append(pc.preChecks, check)
+	return pc
+}
+
+// SetReuseExistingBuildInfo opts into looking up build-info already recorded in Artifactory for an image with
+// the same manifest digest (e.g. from an earlier push of the identical image) and reusing its artifact and
+// dependency list in the saved module. The registry is still walked for the new push; the artifacts that walk
+// yields are replaced by the reused ones. Only consulted when --validate-sha is set, since that's
+// the only path with a manifest digest to look up by. Off by default: a re-push is usually expected to refresh
+// build-info from the registry, not silently reuse a prior run's.
+func (pc *PushCommand) SetReuseExistingBuildInfo(reuseExistingBuildInfo bool) *PushCommand {
+	pc.reuseExistingBuildInfo = reuseExistingBuildInfo
+	return pc
+}
+
+func (pc *PushCommand) IsReuseExistingBuildInfo() bool {
+	return pc.reuseExistingBuildInfo
+}
+
 func (pc *PushCommand) Result() *commandsutils.Result {
 	return pc.result
 }
@@ -81,17 +196,49 @@ func (pc *PushCommand) Run() error {
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
+	// Resolve short (unqualified) image references to a fully-qualified Artifactory reference before login/push,
+	// so the native docker command, the login target and the recorded build-info all agree on the same reference.
+	if err := pc.resolveShortName(serverDetails); err != nil {
+		return err
+	}
 	// Perform login
 	if err := pc.PerformLogin(serverDetails, pc.containerManagerType); err != nil {
 		return err
 	}
-	// Perform push.
 	cm := containerutils.NewManager(pc.containerManagerType)
+
+	serviceManager, err := utils.CreateServiceManagerWithThreads(serverDetails, false, pc.threads, -1, 0)
+	if err != nil {
+		return err
+	}
+	repo, err := pc.GetRepo()
+	if err != nil {
+		return err
+	}
+
+	if !pc.IsSkipPrechecks() {
+		if err := pc.runPrechecks(cm, serverDetails, repo); err != nil {
+			return err
+		}
+	}
+
+	// Perform push.
 	err = cm.RunNativeCmd(pc.cmdParams)
 	if err != nil {
 		return err
 	}
 
+	var signatureDetails []clientutils.FileTransferDetails
+	if pc.IsSignImage() {
+		signatureDetails, err = pc.signAndUploadImage(cm, serverDetails, repo)
+		if err != nil {
+			if !pc.IsSignOptional() {
+				return errorutils.CheckError(fmt.Errorf("failed to sign pushed image: %w", err))
+			}
+			log.Warn("Signing the pushed image failed, continuing because --sign-optional was set: " + err.Error())
+		}
+	}
+
 	toCollect, err := pc.buildConfiguration.IsCollectBuildInfo()
 	if err != nil {
 		return err
@@ -107,14 +254,6 @@ func (pc *PushCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	serviceManager, err := utils.CreateServiceManagerWithThreads(serverDetails, false, pc.threads, -1, 0)
-	if err != nil {
-		return err
-	}
-	repo, err := pc.GetRepo()
-	if err != nil {
-		return err
-	}
 
 	// If SHA validation is enabled, log it
 	if pc.IsValidateSha() {
@@ -126,12 +265,53 @@ func (pc *PushCommand) Run() error {
 		}
 		log.Debug("Using image SHA256 for validation: " + imageSha256)
 
+		// The resolved manifest digest, not the local image config ID, is what identifies this exact push in
+		// the registry (and in any previously-recorded build-info for it), so it's used below for both
+		// multi-arch validation and build-info reuse instead of imageSha256.
+		manifestDigest, err := pushutil.GetManifestDigest(serverDetails, repo, pc.image, imageSha256)
+		if err != nil {
+			return err
+		}
+
+		// Collecting per-platform modules also tells us whether pc.image is a multi-arch index. This is
+		// best-effort: a failure here (e.g. a registry hiccup on the extra manifest GET) must not fail the
+		// command, since the image has already been pushed successfully by this point.
+		platformModules, err := pc.collectPlatformModules(serverDetails, repo)
+		if err != nil {
+			log.Debug("Could not determine whether " + pc.image + " is a multi-arch index, skipping per-platform build-info: " + err.Error())
+			platformModules = nil
+		}
+
+		// A multi-arch index has no single local image SHA to validate against - there is no "the" image,
+		// only its children - so it's walked and validated by its own manifest digest instead.
+		validationDigest := imageSha256
+		if len(platformModules) > 0 {
+			validationDigest = strings.TrimPrefix(manifestDigest, "sha256:")
+		}
+
 		// Use RemoteAgentBuildInfoBuilder for SHA-based validation
-		remoteBuilder, err := containerutils.NewRemoteAgentBuildInfoBuilder(pc.image, repo, buildName, buildNumber, pc.BuildConfiguration().GetProject(), serviceManager, imageSha256)
+		remoteBuilder, err := containerutils.NewRemoteAgentBuildInfoBuilder(pc.image, repo, buildName, buildNumber, pc.BuildConfiguration().GetProject(), serviceManager, validationDigest)
 		if err != nil {
 			return err
 		}
 
+		// If an identical image (same manifest digest) was already pushed and has build-info recorded in
+		// Artifactory, its artifacts and dependencies replace the ones collected below by remoteBuilder.Build,
+		// which still walks the registry. Opt-in only: see SetReuseExistingBuildInfo.
+		var existingModule *entities.Module
+		if pc.IsReuseExistingBuildInfo() {
+			// Keyed by the resolved manifest digest, not imageSha256 (the local image's config ID): those
+			// differ for a manifest, and the AQL lookup inside LoadExistingModuleByDigest needs the digest
+			// that's actually recorded against the artifact in Artifactory.
+			existingModule, err = pushutil.LoadExistingModuleByDigest(serverDetails, manifestDigest)
+			if err != nil {
+				log.Debug("Could not look up existing build-info for digest " + manifestDigest + ": " + err.Error())
+				existingModule = nil
+			} else if existingModule != nil {
+				log.Info("Found existing build-info for digest " + manifestDigest + ", reusing its layers in the saved build-info")
+			}
+		}
+
 		if toCollect {
 			if err := build.SaveBuildGeneralDetails(buildName, buildNumber, pc.buildConfiguration.GetProject()); err != nil {
 				return err
@@ -143,9 +323,14 @@ func (pc *PushCommand) Run() error {
 			if buildInfoModule == nil {
 				return errorutils.CheckError(fmt.Errorf("failed to create build info module: module is nil"))
 			}
+			buildInfoModule = pushutil.MergeExistingModule(existingModule, buildInfoModule)
+			pushutil.AddSignatureArtifacts(buildInfoModule, signatureDetails)
 			if err = build.SaveBuildInfo(buildName, buildNumber, pc.BuildConfiguration().GetProject(), buildInfoModule); err != nil {
 				return errorutils.CheckError(fmt.Errorf("failed to save build info: %w", err))
 			}
+			if err = pc.savePlatformModules(buildName, buildNumber, platformModules); err != nil {
+				return errorutils.CheckError(err)
+			}
 		}
 
 		if pc.IsDetailedSummary() {
@@ -155,7 +340,7 @@ func (pc *PushCommand) Run() error {
 					return errorutils.CheckError(fmt.Errorf("failed to build summary info: %w", err))
 				}
 			}
-			return pc.layersMapToFileTransferDetails(serverDetails.ArtifactoryUrl, remoteBuilder.GetLayers())
+			return pc.layersMapToFileTransferDetails(serverDetails.ArtifactoryUrl, remoteBuilder.GetLayers(), signatureDetails)
 		}
 		return nil
 	}
@@ -166,6 +351,14 @@ func (pc *PushCommand) Run() error {
 		return err
 	}
 
+	// Best-effort: a failure here must not fail the command, since the image has already been pushed
+	// successfully by this point.
+	platformModules, err := pc.collectPlatformModules(serverDetails, repo)
+	if err != nil {
+		log.Debug("Could not determine whether " + pc.image + " is a multi-arch index, skipping per-platform build-info: " + err.Error())
+		platformModules = nil
+	}
+
 	if toCollect {
 		if err := build.SaveBuildGeneralDetails(buildName, buildNumber, pc.buildConfiguration.GetProject()); err != nil {
 			return err
@@ -174,9 +367,13 @@ func (pc *PushCommand) Run() error {
 		if err != nil || buildInfoModule == nil {
 			return err
 		}
+		pushutil.AddSignatureArtifacts(buildInfoModule, signatureDetails)
 		if err = build.SaveBuildInfo(buildName, buildNumber, pc.BuildConfiguration().GetProject(), buildInfoModule); err != nil {
 			return err
 		}
+		if err = pc.savePlatformModules(buildName, buildNumber, platformModules); err != nil {
+			return err
+		}
 	}
 
 	if pc.IsDetailedSummary() {
@@ -189,13 +386,119 @@ func (pc *PushCommand) Run() error {
 				return err
 			}
 		}
-		return pc.layersMapToFileTransferDetails(serverDetails.ArtifactoryUrl, builder.GetLayers())
+		return pc.layersMapToFileTransferDetails(serverDetails.ArtifactoryUrl, builder.GetLayers(), signatureDetails)
 	}
 
 	return nil
 }
 
-func (pc *PushCommand) layersMapToFileTransferDetails(artifactoryUrl string, layers *[]servicesutils.ResultItem) error {
+// runPrechecks runs the built-in and user-registered ContainerPushPreCheck implementations in parallel and
+// aggregates their failures into a single error, one line per failing check.
+func (pc *PushCommand) runPrechecks(cm containerutils.ContainerManager, serverDetails *config.ServerDetails, repo string) error {
+	checks := append([]pushutil.ContainerPushPreCheck{
+		pushutil.NewRepoExistsPreCheck(serverDetails, repo),
+		pushutil.NewRepoLayoutPreCheck(serverDetails, repo, pc.image),
+		pushutil.NewPushSizeQuotaPreCheck(serverDetails, cm, repo, pc.image),
+		pushutil.NewTagImmutabilityPreCheck(serverDetails, repo, pc.image),
+	}, pc.preChecks...)
+	runner := pushutil.NewPreCheckRunner(checks)
+	return runner.Run()
+}
+
+// resolveShortName rewrites an unqualified image reference (e.g. "myimg:1.0") into a fully-qualified one
+// (e.g. "my-artifactory.example.com/docker-local/myimg:1.0") according to the configured short-name mode,
+// and updates pc.image and pc.cmdParams in place so the native command, login and build-info all use it.
+func (pc *PushCommand) resolveShortName(serverDetails *config.ServerDetails) error {
+	mode := pc.ShortNameMode()
+	if mode == "" {
+		mode = pushutil.ShortNameModeForServer(serverDetails)
+	}
+	if mode == pushutil.ShortNameModeDisabled {
+		return nil
+	}
+	resolver, err := pushutil.NewShortNameResolver(mode, serverDetails, pc.ShortNameAliases())
+	if err != nil {
+		return err
+	}
+	resolvedImage, err := resolver.Resolve(pc.image)
+	if err != nil {
+		return errorutils.CheckError(fmt.Errorf("failed to resolve short image name %q: %w", pc.image, err))
+	}
+	if resolvedImage == pc.image {
+		return nil
+	}
+	log.Debug("Resolved short image name " + pc.image + " to " + resolvedImage)
+	for i, param := range pc.cmdParams {
+		if param == pc.image {
+			pc.cmdParams[i] = resolvedImage
+		}
+	}
+	pc.image = resolvedImage
+	return nil
+}
+
+// signAndUploadImage resolves the pushed manifest's digest, signs it with the configured signer and uploads
+// the resulting signature artifact(s) to Artifactory, returning their transfer details for the build-info
+// and summary.
+func (pc *PushCommand) signAndUploadImage(cm containerutils.ContainerManager, serverDetails *config.ServerDetails, repo string) ([]clientutils.FileTransferDetails, error) {
+	imageSha256, err := cm.Id(pc.image)
+	if err != nil {
+		return nil, err
+	}
+	manifestDigest, err := pushutil.GetManifestDigest(serverDetails, repo, pc.image, imageSha256)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := pushutil.NewImageSigner(serverDetails, pc.SigningKeyRef())
+	if err != nil {
+		return nil, err
+	}
+	signatureRepo := pc.SignatureRepo()
+	if signatureRepo == "" {
+		signatureRepo = repo
+	}
+	log.Info("Signing pushed image manifest " + manifestDigest + " with " + signer.Name())
+	signatureDetails, err := pushutil.SignAndUploadManifest(signer, serverDetails, signatureRepo, pc.image, manifestDigest)
+	if err != nil {
+		return nil, err
+	}
+	return signatureDetails, nil
+}
+
+// collectPlatformModules detects whether pc.image resolves to a multi-arch OCI image index or Docker manifest
+// list and, if so, returns one build-info module per child platform (named "<module>/<os>-<arch>[/<variant>]",
+// restricted to pc.Platforms() when set), each carrying that platform's own layer and config blobs, so
+// build-info records each architecture individually instead of only the index's own flat layer list. Returns
+// nil, nil for a plain single-arch push. Callers treat a non-nil error as best-effort: the image has already
+// been pushed by the time this runs.
+func (pc *PushCommand) collectPlatformModules(serverDetails *config.ServerDetails, repo string) ([]entities.Module, error) {
+	named, err := pushutil.ParseNamed(pc.image)
+	if err != nil {
+		return nil, err
+	}
+	_, children, isIndex, err := pushutil.FetchIndex(serverDetails, repo, named)
+	if err != nil {
+		return nil, err
+	}
+	if !isIndex {
+		return nil, nil
+	}
+	platforms := pushutil.FilterPlatforms(children, pc.Platforms())
+	return pushutil.BuildPlatformModules(serverDetails, repo, named, pc.BuildConfiguration().GetModule(), platforms)
+}
+
+// savePlatformModules records each per-platform module as its own build-info module, mirroring how other
+// multi-module jfrog-cli commands accumulate a build's partial build-info across several SaveBuildInfo calls.
+func (pc *PushCommand) savePlatformModules(buildName, buildNumber string, platformModules []entities.Module) error {
+	for i := range platformModules {
+		if err := build.SaveBuildInfo(buildName, buildNumber, pc.BuildConfiguration().GetProject(), &platformModules[i]); err != nil {
+			return fmt.Errorf("failed to save build info for %s: %w", platformModules[i].Id, err)
+		}
+	}
+	return nil
+}
+
+func (pc *PushCommand) layersMapToFileTransferDetails(artifactoryUrl string, layers *[]servicesutils.ResultItem, signatureDetails []clientutils.FileTransferDetails) error {
 	var details []clientutils.FileTransferDetails
 	for _, layer := range *layers {
 		sha256 := ""
@@ -206,6 +509,7 @@ func (pc *PushCommand) layersMapToFileTransferDetails(artifactoryUrl string, lay
 		}
 		details = append(details, clientutils.FileTransferDetails{TargetPath: path.Join(layer.Repo, layer.Path, layer.Name), RtUrl: artifactoryUrl, Sha256: sha256})
 	}
+	details = append(details, signatureDetails...)
 	tempFile, err := clientutils.SaveFileTransferDetailsInTempFile(&details)
 	if err != nil {
 		return err
@@ -0,0 +1,25 @@
+package pushutil
+
+import (
+	"path"
+
+	"github.com/jfrog/build-info-go/entities"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+)
+
+// AddSignatureArtifacts records the uploaded signature file(s) as artifacts of module, so that `jfrog rt bp`
+// promotes them atomically with the image they sign.
+func AddSignatureArtifacts(module *entities.Module, signatureDetails []clientutils.FileTransferDetails) {
+	if module == nil {
+		return
+	}
+	for _, details := range signatureDetails {
+		module.Artifacts = append(module.Artifacts, entities.Artifact{
+			Name: path.Base(details.TargetPath),
+			Path: details.TargetPath,
+			Checksum: entities.Checksum{
+				Sha256: details.Sha256,
+			},
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package pushutil
+
+import (
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSignatureArtifacts(t *testing.T) {
+	module := &entities.Module{Id: "myimage"}
+	AddSignatureArtifacts(module, []clientutils.FileTransferDetails{
+		{TargetPath: "docker-local/myimage/sha256-abc.sig", Sha256: "def"},
+	})
+	require.Len(t, module.Artifacts, 1)
+	assert.Equal(t, "sha256-abc.sig", module.Artifacts[0].Name)
+	assert.Equal(t, "def", module.Artifacts[0].Checksum.Sha256)
+}
+
+func TestAddSignatureArtifacts_NilModule(t *testing.T) {
+	assert.NotPanics(t, func() {
+		AddSignatureArtifacts(nil, []clientutils.FileTransferDetails{{TargetPath: "x"}})
+	})
+}
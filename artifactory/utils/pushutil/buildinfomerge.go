@@ -0,0 +1,97 @@
+package pushutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type aqlSearchResult struct {
+	Results []struct {
+		Repo string `json:"repo"`
+		Path string `json:"path"`
+		Name string `json:"name"`
+	} `json:"results"`
+}
+
+// LoadExistingModuleByDigest searches Artifactory for build-info previously recorded for an image with the
+// same manifest digest (e.g. from an earlier push, promotion or retag of the identical image) and returns
+// its module, or nil if none is found. On a re-push of a byte-identical image, MergeExistingModule can then
+// carry that module's artifacts and dependencies over into the new build.
+func LoadExistingModuleByDigest(serverDetails *config.ServerDetails, manifestDigest string) (*entities.Module, error) {
+	sha256 := strings.TrimPrefix(manifestDigest, "sha256:")
+	aql := fmt.Sprintf(`items.find({"@sha256":{"$eq":"%s"},"name":{"$match":"*.json"}})`, sha256)
+	status, body, err := postAql(serverDetails, aql)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError("searching for existing build-info by digest", status, body)
+	}
+	var results aqlSearchResult
+	if jsonErr := json.Unmarshal(body, &results); jsonErr != nil {
+		return nil, errorutils.CheckError(jsonErr)
+	}
+	if len(results.Results) == 0 {
+		return nil, nil
+	}
+	first := results.Results[0]
+	status, body, err = doArtifactoryRequest(serverDetails, http.MethodGet, strings.Join([]string{first.Repo, first.Path, first.Name}, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError("downloading existing build-info "+first.Name, status, body)
+	}
+	var buildInfo entities.BuildInfo
+	if jsonErr := json.Unmarshal(body, &buildInfo); jsonErr != nil {
+		return nil, errorutils.CheckError(jsonErr)
+	}
+	if len(buildInfo.Modules) == 0 {
+		return nil, nil
+	}
+	module := buildInfo.Modules[0]
+	return &module, nil
+}
+
+// MergeExistingModule overlays newModule's identity (name/type) onto existing's artifact/dependency list,
+// analogous to Docker's runconfig.Merge: the new build's metadata wins, but every artifact SHA, size and
+// original timestamp from the prior push of the identical digest is preserved byte-for-byte.
+func MergeExistingModule(existing, newModule *entities.Module) *entities.Module {
+	if existing == nil {
+		return newModule
+	}
+	if newModule == nil {
+		return existing
+	}
+	merged := *existing
+	merged.Id = newModule.Id
+	merged.Type = newModule.Type
+	return &merged
+}
+
+func postAql(serverDetails *config.ServerDetails, aql string) (status int, body []byte, err error) {
+	reqUrl := strings.TrimSuffix(serverDetails.ArtifactoryUrl, "/") + "/api/search/aql"
+	req, err := http.NewRequest(http.MethodPost, reqUrl, strings.NewReader(aql))
+	if err != nil {
+		return 0, nil, errorutils.CheckError(err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	setAuth(req, serverDetails)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, errorutils.CheckError(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, errorutils.CheckError(err)
+	}
+	return resp.StatusCode, data, nil
+}
@@ -0,0 +1,60 @@
+package pushutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadExistingModuleByDigest_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/search/aql", r.URL.Path)
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer server.Close()
+
+	module, err := LoadExistingModuleByDigest(&config.ServerDetails{ArtifactoryUrl: server.URL}, "sha256:abcdef")
+	require.NoError(t, err)
+	assert.Nil(t, module)
+}
+
+func TestLoadExistingModuleByDigest_Found(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/search/aql":
+			_, _ = w.Write([]byte(`{"results":[{"repo":"artifactory-build-info","path":"myimage/1","name":"myimage-1.json"}]}`))
+		case "/artifactory-build-info/myimage/1/myimage-1.json":
+			_, _ = w.Write([]byte(`{"modules":[{"id":"old-module","type":"docker","artifacts":[{"name":"layer1","sha256":"deadbeef"}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	module, err := LoadExistingModuleByDigest(&config.ServerDetails{ArtifactoryUrl: server.URL}, "sha256:abcdef")
+	require.NoError(t, err)
+	require.NotNil(t, module)
+	assert.Equal(t, "old-module", module.Id)
+	require.Len(t, module.Artifacts, 1)
+	assert.Equal(t, "layer1", module.Artifacts[0].Name)
+}
+
+func TestMergeExistingModule(t *testing.T) {
+	existing := &entities.Module{Id: "old-module", Type: "docker", Artifacts: []entities.Artifact{{Name: "layer1"}}}
+	newModule := &entities.Module{Id: "new-build/new-module", Type: "docker"}
+
+	merged := MergeExistingModule(existing, newModule)
+	assert.Equal(t, "new-build/new-module", merged.Id)
+	require.Len(t, merged.Artifacts, 1)
+	assert.Equal(t, "layer1", merged.Artifacts[0].Name)
+}
+
+func TestMergeExistingModule_NilExisting(t *testing.T) {
+	newModule := &entities.Module{Id: "new-module"}
+	assert.Same(t, newModule, MergeExistingModule(nil, newModule))
+}
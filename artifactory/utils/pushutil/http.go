@@ -0,0 +1,52 @@
+// Package pushutil implements the container-push subsystems (signing, short-name resolution, multi-arch
+// build-info, pre-push checks and build-info reuse) that artifactory/commands/container.PushCommand builds on.
+//
+// These subsystems talk to Artifactory directly over HTTP rather than through
+// github.com/jfrog/jfrog-client-go/artifactory.ArtifactoryServicesManager, since none of them need more than a
+// handful of REST calls and a generic services manager doesn't expose raw registry/AQL primitives.
+package pushutil
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// doArtifactoryRequest issues an authenticated request against serverDetails.ArtifactoryUrl + urlPath and
+// returns the response body. The caller is responsible for interpreting status codes that aren't 2xx.
+func doArtifactoryRequest(serverDetails *config.ServerDetails, method, urlPath string) (status int, body []byte, err error) {
+	reqUrl := strings.TrimSuffix(serverDetails.ArtifactoryUrl, "/") + "/" + strings.TrimPrefix(urlPath, "/")
+	req, err := http.NewRequest(method, reqUrl, nil)
+	if err != nil {
+		return 0, nil, errorutils.CheckError(err)
+	}
+	setAuth(req, serverDetails)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, errorutils.CheckError(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, errorutils.CheckError(err)
+	}
+	return resp.StatusCode, data, nil
+}
+
+func setAuth(req *http.Request, serverDetails *config.ServerDetails) {
+	if serverDetails.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+serverDetails.AccessToken)
+		return
+	}
+	if serverDetails.User != "" {
+		req.SetBasicAuth(serverDetails.User, serverDetails.Password)
+	}
+}
+
+func statusError(action string, status int, body []byte) error {
+	return errorutils.CheckError(fmt.Errorf("%s: unexpected status %d: %s", action, status, string(body)))
+}
@@ -0,0 +1,77 @@
+package pushutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type blobDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type singlePlatformManifest struct {
+	Config blobDescriptor   `json:"config"`
+	Layers []blobDescriptor `json:"layers"`
+}
+
+// fetchPlatformBlobs fetches platform's own (single-arch) manifest from repo and returns its config and layer
+// blob descriptors, in the order they should be recorded as build-info artifacts.
+func fetchPlatformBlobs(serverDetails *config.ServerDetails, repo string, named Named, platform PlatformManifest) ([]blobDescriptor, error) {
+	urlPath := fmt.Sprintf("v2/%s/%s/manifests/%s", repo, named.Repository, platform.Digest)
+	status, body, err := doArtifactoryRequest(serverDetails, http.MethodGet, urlPath)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError("fetching platform manifest "+urlPath, status, body)
+	}
+	var parsed singlePlatformManifest
+	if jsonErr := json.Unmarshal(body, &parsed); jsonErr != nil {
+		return nil, errorutils.CheckError(jsonErr)
+	}
+	blobs := make([]blobDescriptor, 0, len(parsed.Layers)+1)
+	blobs = append(blobs, parsed.Config)
+	blobs = append(blobs, parsed.Layers...)
+	return blobs, nil
+}
+
+// BuildPlatformModules returns one build-info module per platform, named "<baseModuleName>/<os>-<arch>[/<variant>]",
+// populated with an artifact for every layer and config blob of that platform's own manifest. Used to record a
+// multi-arch push (OCI image index / Docker manifest list) as one module per architecture, each carrying its
+// real blobs, instead of a single flat module referencing only the index.
+func BuildPlatformModules(serverDetails *config.ServerDetails, repo string, named Named, baseModuleName string, platforms []PlatformManifest) ([]entities.Module, error) {
+	modules := make([]entities.Module, 0, len(platforms))
+	for _, platform := range platforms {
+		blobs, err := fetchPlatformBlobs(serverDetails, repo, named, platform)
+		if err != nil {
+			return nil, err
+		}
+		artifacts := make([]entities.Artifact, 0, len(blobs))
+		for _, blob := range blobs {
+			artifacts = append(artifacts, entities.Artifact{
+				Name: blob.Digest,
+				Path: path.Join(repo, named.Repository, "blobs", blob.Digest),
+				Checksum: entities.Checksum{
+					// Other build-info recorded here (see layersMapToFileTransferDetails) uses bare hex
+					// digests, so the "sha256:" prefix on blob.Digest is stripped to match.
+					Sha256: strings.TrimPrefix(blob.Digest, "sha256:"),
+				},
+			})
+		}
+		modules = append(modules, entities.Module{
+			Id:        platform.ModuleName(baseModuleName),
+			Type:      "docker",
+			Artifacts: artifacts,
+		})
+	}
+	return modules, nil
+}
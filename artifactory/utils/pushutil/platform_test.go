@@ -0,0 +1,55 @@
+package pushutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPlatformModules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/docker-local/myimage/manifests/sha256:aaa":
+			_, _ = w.Write([]byte(`{"config":{"digest":"sha256:configaaa","size":100},"layers":[{"digest":"sha256:layeraaa1","size":200}]}`))
+		case "/v2/docker-local/myimage/manifests/sha256:bbb":
+			_, _ = w.Write([]byte(`{"config":{"digest":"sha256:configbbb","size":100},"layers":[{"digest":"sha256:layerbbb1","size":200},{"digest":"sha256:layerbbb2","size":300}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	named := Named{Repository: "myimage", Tag: "1.0"}
+	platforms := []PlatformManifest{
+		{Digest: "sha256:aaa", OS: "linux", Architecture: "amd64"},
+		{Digest: "sha256:bbb", OS: "linux", Architecture: "arm64", Variant: "v8"},
+	}
+	modules, err := BuildPlatformModules(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", named, "myimage", platforms)
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	assert.Equal(t, "myimage/linux-amd64", modules[0].Id)
+	require.Len(t, modules[0].Artifacts, 2)
+	assert.Equal(t, "configaaa", modules[0].Artifacts[0].Checksum.Sha256)
+	assert.Equal(t, "layeraaa1", modules[0].Artifacts[1].Checksum.Sha256)
+
+	assert.Equal(t, "myimage/linux-arm64/v8", modules[1].Id)
+	require.Len(t, modules[1].Artifacts, 3)
+	assert.Equal(t, "configbbb", modules[1].Artifacts[0].Checksum.Sha256)
+}
+
+func TestBuildPlatformModules_PropagatesFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	named := Named{Repository: "myimage", Tag: "1.0"}
+	platforms := []PlatformManifest{{Digest: "sha256:aaa", OS: "linux", Architecture: "amd64"}}
+	_, err := BuildPlatformModules(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", named, "myimage", platforms)
+	assert.Error(t, err)
+}
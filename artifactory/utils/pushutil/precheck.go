@@ -0,0 +1,251 @@
+package pushutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// ContainerPushPreCheck is a single validation that must pass before a docker push is attempted. Checks run
+// in parallel; a failing check contributes its error to the aggregated report rather than aborting the
+// others, so a push surfaces every problem at once instead of one at a time.
+type ContainerPushPreCheck interface {
+	// Name identifies the check in the aggregated report.
+	Name() string
+	// Run performs the check, returning a descriptive error on failure.
+	Run() error
+}
+
+// PreCheckRunner runs a set of ContainerPushPreCheck implementations in parallel and aggregates failures
+// into a single error, one line per failing check.
+type PreCheckRunner struct {
+	checks []ContainerPushPreCheck
+}
+
+func NewPreCheckRunner(checks []ContainerPushPreCheck) *PreCheckRunner {
+	return &PreCheckRunner{checks: checks}
+}
+
+// Run executes every registered check concurrently and returns a single aggregated error listing every
+// failure, or nil if all checks passed.
+func (r *PreCheckRunner) Run() error {
+	var wg sync.WaitGroup
+	failures := make([]string, len(r.checks))
+	for i, check := range r.checks {
+		wg.Add(1)
+		go func(i int, check ContainerPushPreCheck) {
+			defer wg.Done()
+			if err := check.Run(); err != nil {
+				failures[i] = fmt.Sprintf("%s: %s", check.Name(), err.Error())
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	var reported []string
+	for _, failure := range failures {
+		if failure != "" {
+			reported = append(reported, failure)
+		}
+	}
+	if len(reported) == 0 {
+		return nil
+	}
+	return errorutils.CheckError(fmt.Errorf("pre-push validation failed:\n  - %s", strings.Join(reported, "\n  - ")))
+}
+
+// dockerRepoComponentPattern is Docker's grammar for a single path component of an image reference.
+var dockerRepoComponentPattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+
+type repoConfiguration struct {
+	Rclass               string  `json:"rclass"`
+	PackageType          string  `json:"packageType"`
+	DockerApiVersion     string  `json:"dockerApiVersion"`
+	DockerRepositoryPath string  `json:"dockerRepositoryPath,omitempty"`
+	SpaceQuotaGB         float64 `json:"spaceQuotaGb,omitempty"`
+}
+
+func fetchRepoConfiguration(serverDetails *config.ServerDetails, repo string) (*repoConfiguration, error) {
+	status, body, err := doArtifactoryRequest(serverDetails, http.MethodGet, "api/repositories/"+repo)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError("fetching repository configuration for "+repo, status, body)
+	}
+	var cfg repoConfiguration
+	if jsonErr := json.Unmarshal(body, &cfg); jsonErr != nil {
+		return nil, errorutils.CheckError(jsonErr)
+	}
+	return &cfg, nil
+}
+
+// RepoExistsPreCheck verifies that repo exists in Artifactory and is a Docker repository speaking V2,
+// failing fast instead of letting the native push fail later with a confusing registry error.
+type RepoExistsPreCheck struct {
+	serverDetails *config.ServerDetails
+	repo          string
+}
+
+func NewRepoExistsPreCheck(serverDetails *config.ServerDetails, repo string) *RepoExistsPreCheck {
+	return &RepoExistsPreCheck{serverDetails: serverDetails, repo: repo}
+}
+
+func (c *RepoExistsPreCheck) Name() string { return "repo-exists" }
+
+func (c *RepoExistsPreCheck) Run() error {
+	cfg, err := fetchRepoConfiguration(c.serverDetails, c.repo)
+	if err != nil {
+		return err
+	}
+	if cfg.PackageType != "docker" {
+		return fmt.Errorf("repository %q is of type %q, not docker", c.repo, cfg.PackageType)
+	}
+	if cfg.DockerApiVersion != "" && cfg.DockerApiVersion != "V2" {
+		return fmt.Errorf("repository %q is configured for Docker API %q, only V2 is supported", c.repo, cfg.DockerApiVersion)
+	}
+	return nil
+}
+
+// RepoLayoutPreCheck validates that repo's name conforms to Docker's component grammar and that the
+// resolved image reference splits cleanly against the repo's configured dockerRepositoryPath prefix.
+type RepoLayoutPreCheck struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	imageRef      string
+}
+
+func NewRepoLayoutPreCheck(serverDetails *config.ServerDetails, repo, imageRef string) *RepoLayoutPreCheck {
+	return &RepoLayoutPreCheck{serverDetails: serverDetails, repo: repo, imageRef: imageRef}
+}
+
+func (c *RepoLayoutPreCheck) Name() string { return "repo-layout" }
+
+func (c *RepoLayoutPreCheck) Run() error {
+	if !dockerRepoComponentPattern.MatchString(c.repo) {
+		return fmt.Errorf("repository name %q doesn't conform to Docker's component grammar", c.repo)
+	}
+	cfg, err := fetchRepoConfiguration(c.serverDetails, c.repo)
+	if err != nil {
+		return err
+	}
+	if cfg.DockerRepositoryPath == "" {
+		return nil
+	}
+	named, err := ParseNamed(c.imageRef)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(named.Repository, cfg.DockerRepositoryPath) {
+		return fmt.Errorf("image reference %q doesn't start with repository %q's configured path %q", c.imageRef, c.repo, cfg.DockerRepositoryPath)
+	}
+	return nil
+}
+
+// ContainerManager is the subset of containerutils.ContainerManager that PushSizeQuotaPreCheck needs to
+// confirm the image about to be pushed exists locally.
+type ContainerManager interface {
+	Id(image string) (string, error)
+}
+
+// localImageSizeBytes reports the on-disk size, in bytes, of imageRef's local image (`docker image
+// inspect`'s .Size field). It's a var so tests can substitute a fake instead of requiring a local docker daemon.
+var localImageSizeBytes = func(imageRef string) (int64, error) {
+	out, err := exec.Command("docker", "image", "inspect", imageRef, "--format", "{{.Size}}").Output()
+	if err != nil {
+		return 0, errorutils.CheckError(fmt.Errorf("inspecting local image %q: %w", imageRef, err))
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return 0, errorutils.CheckError(fmt.Errorf("parsing size of local image %q: %w", imageRef, err))
+	}
+	return size, nil
+}
+
+// PushSizeQuotaPreCheck compares the size of the local image about to be pushed against repo's configured
+// push size quota (repoConfiguration.SpaceQuotaGB), failing fast instead of letting the push run to
+// completion and be rejected by Artifactory partway through.
+type PushSizeQuotaPreCheck struct {
+	serverDetails *config.ServerDetails
+	cm            ContainerManager
+	repo          string
+	imageRef      string
+}
+
+func NewPushSizeQuotaPreCheck(serverDetails *config.ServerDetails, cm ContainerManager, repo, imageRef string) *PushSizeQuotaPreCheck {
+	return &PushSizeQuotaPreCheck{serverDetails: serverDetails, cm: cm, repo: repo, imageRef: imageRef}
+}
+
+func (c *PushSizeQuotaPreCheck) Name() string { return "push-size-quota" }
+
+func (c *PushSizeQuotaPreCheck) Run() error {
+	// Confirms the image exists locally before the native push is attempted, giving a clear error instead of
+	// letting the native push fail later for the same reason.
+	if _, err := c.cm.Id(c.imageRef); err != nil {
+		return err
+	}
+	cfg, err := fetchRepoConfiguration(c.serverDetails, c.repo)
+	if err != nil {
+		return err
+	}
+	if cfg.SpaceQuotaGB <= 0 {
+		// No push size quota configured for this repo; nothing to enforce.
+		return nil
+	}
+	sizeBytes, err := localImageSizeBytes(c.imageRef)
+	if err != nil {
+		return err
+	}
+	quotaBytes := int64(cfg.SpaceQuotaGB * 1024 * 1024 * 1024)
+	if sizeBytes > quotaBytes {
+		return fmt.Errorf("image %q is %d bytes, exceeding repository %q's %.2fGB push size quota", c.imageRef, sizeBytes, c.repo, cfg.SpaceQuotaGB)
+	}
+	return nil
+}
+
+// TagImmutabilityPreCheck rejects pushing to a tag that already exists and is marked immutable or
+// retention-locked.
+type TagImmutabilityPreCheck struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	imageRef      string
+}
+
+func NewTagImmutabilityPreCheck(serverDetails *config.ServerDetails, repo, imageRef string) *TagImmutabilityPreCheck {
+	return &TagImmutabilityPreCheck{serverDetails: serverDetails, repo: repo, imageRef: imageRef}
+}
+
+func (c *TagImmutabilityPreCheck) Name() string { return "tag-immutability" }
+
+func (c *TagImmutabilityPreCheck) Run() error {
+	named, err := ParseNamed(c.imageRef)
+	if err != nil {
+		return err
+	}
+	if named.IsDigested() {
+		// Digest-qualified pushes never collide with an existing tag.
+		return nil
+	}
+	status, body, err := doArtifactoryRequest(c.serverDetails, http.MethodGet, fmt.Sprintf("api/storage/%s/%s/%s/manifest.json?properties=artifactory.locked", c.repo, named.Repository, named.Tag))
+	if status == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return statusError("checking tag immutability for "+c.imageRef, status, body)
+	}
+	if strings.Contains(string(body), `"artifactory.locked":["true"]`) {
+		return fmt.Errorf("tag %q already exists and is immutable/retention-locked", c.imageRef)
+	}
+	return nil
+}
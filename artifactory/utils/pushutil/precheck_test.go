@@ -0,0 +1,152 @@
+package pushutil
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCheck struct {
+	name string
+	err  error
+}
+
+func (f *fakeCheck) Name() string { return f.name }
+func (f *fakeCheck) Run() error   { return f.err }
+
+func TestPreCheckRunner_AllPass(t *testing.T) {
+	runner := NewPreCheckRunner([]ContainerPushPreCheck{
+		&fakeCheck{name: "a"},
+		&fakeCheck{name: "b"},
+	})
+	assert.NoError(t, runner.Run())
+}
+
+func TestPreCheckRunner_AggregatesFailures(t *testing.T) {
+	runner := NewPreCheckRunner([]ContainerPushPreCheck{
+		&fakeCheck{name: "a", err: errors.New("boom-a")},
+		&fakeCheck{name: "b"},
+		&fakeCheck{name: "c", err: errors.New("boom-c")},
+	})
+	err := runner.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a: boom-a")
+	assert.Contains(t, err.Error(), "c: boom-c")
+	assert.NotContains(t, err.Error(), "b:")
+}
+
+func TestRepoExistsPreCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/repositories/docker-local", r.URL.Path)
+		_, _ = w.Write([]byte(`{"packageType":"docker","dockerApiVersion":"V2"}`))
+	}))
+	defer server.Close()
+
+	check := NewRepoExistsPreCheck(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local")
+	assert.NoError(t, check.Run())
+}
+
+func TestRepoExistsPreCheck_WrongPackageType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"packageType":"maven","dockerApiVersion":"V2"}`))
+	}))
+	defer server.Close()
+
+	check := NewRepoExistsPreCheck(&config.ServerDetails{ArtifactoryUrl: server.URL}, "maven-local")
+	assert.Error(t, check.Run())
+}
+
+func TestRepoLayoutPreCheck_InvalidRepoName(t *testing.T) {
+	check := NewRepoLayoutPreCheck(&config.ServerDetails{}, "Docker_Local!", "myimg:1.0")
+	assert.Error(t, check.Run())
+}
+
+func TestRepoLayoutPreCheck_Valid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"packageType":"docker"}`))
+	}))
+	defer server.Close()
+
+	check := NewRepoLayoutPreCheck(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", "myimg:1.0")
+	assert.NoError(t, check.Run())
+}
+
+type fakeContainerManager struct {
+	id  string
+	err error
+}
+
+func (f *fakeContainerManager) Id(string) (string, error) { return f.id, f.err }
+
+func TestPushSizeQuotaPreCheck_FailsWhenImageMissingLocally(t *testing.T) {
+	check := NewPushSizeQuotaPreCheck(&config.ServerDetails{}, &fakeContainerManager{err: errors.New("no such image")}, "docker-local", "myimg:1.0")
+	assert.Error(t, check.Run())
+}
+
+func TestPushSizeQuotaPreCheck_PassesWhenNoQuotaConfigured(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	check := NewPushSizeQuotaPreCheck(&config.ServerDetails{ArtifactoryUrl: server.URL}, &fakeContainerManager{id: "sha256:abc"}, "docker-local", "myimg:1.0")
+	assert.NoError(t, check.Run())
+}
+
+func TestPushSizeQuotaPreCheck_WithinQuota(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"spaceQuotaGb":10}`))
+	}))
+	defer server.Close()
+
+	original := localImageSizeBytes
+	localImageSizeBytes = func(string) (int64, error) { return 1024, nil }
+	defer func() { localImageSizeBytes = original }()
+
+	check := NewPushSizeQuotaPreCheck(&config.ServerDetails{ArtifactoryUrl: server.URL}, &fakeContainerManager{id: "sha256:abc"}, "docker-local", "myimg:1.0")
+	assert.NoError(t, check.Run())
+}
+
+func TestPushSizeQuotaPreCheck_ExceedsQuota(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"spaceQuotaGb":0.000001}`))
+	}))
+	defer server.Close()
+
+	original := localImageSizeBytes
+	localImageSizeBytes = func(string) (int64, error) { return 10_000_000, nil }
+	defer func() { localImageSizeBytes = original }()
+
+	check := NewPushSizeQuotaPreCheck(&config.ServerDetails{ArtifactoryUrl: server.URL}, &fakeContainerManager{id: "sha256:abc"}, "docker-local", "myimg:1.0")
+	assert.Error(t, check.Run())
+}
+
+func TestTagImmutabilityPreCheck_NotFoundIsOk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	check := NewTagImmutabilityPreCheck(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", "myimg:1.0")
+	assert.NoError(t, check.Run())
+}
+
+func TestTagImmutabilityPreCheck_LockedTagFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"properties":{"artifactory.locked":["true"]}}`))
+	}))
+	defer server.Close()
+
+	check := NewTagImmutabilityPreCheck(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", "myimg:1.0")
+	assert.Error(t, check.Run())
+}
+
+func TestTagImmutabilityPreCheck_DigestSkipsCheck(t *testing.T) {
+	check := NewTagImmutabilityPreCheck(&config.ServerDetails{}, "docker-local", "myimg@sha256:abcdef")
+	assert.NoError(t, check.Run())
+}
@@ -0,0 +1,142 @@
+package pushutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// MediaTypeOCIImageIndex is the OCI 1.0 multi-platform manifest list media type.
+	MediaTypeOCIImageIndex = "application/vnd.oci.image.index.v1+json"
+	// MediaTypeDockerManifestList is the legacy Docker multi-platform manifest list media type, produced by
+	// `docker manifest push` and older `docker buildx` builds.
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// Named is a parsed image reference, distinguishing a tag-qualified reference from a digest-qualified one,
+// mirroring the distribution reference package's Tagged/Digested split.
+type Named struct {
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// IsDigested reports whether the reference was qualified by digest (repo/image@sha256:...) rather than by tag.
+func (n Named) IsDigested() bool {
+	return n.Digest != ""
+}
+
+// ParseNamed splits an image reference into its repository and Tagged/Digested qualifier.
+func ParseNamed(imageRef string) (Named, error) {
+	if imageRef == "" {
+		return Named{}, errorutils.CheckError(fmt.Errorf("image reference must not be empty"))
+	}
+	if idx := strings.Index(imageRef, "@"); idx >= 0 {
+		return Named{Repository: imageRef[:idx], Digest: imageRef[idx+1:]}, nil
+	}
+	if idx := strings.LastIndex(imageRef, ":"); idx >= 0 && !strings.Contains(imageRef[idx:], "/") {
+		return Named{Repository: imageRef[:idx], Tag: imageRef[idx+1:]}, nil
+	}
+	return Named{Repository: imageRef, Tag: "latest"}, nil
+}
+
+// IsIndexMediaType reports whether mediaType identifies an OCI image index or Docker manifest list.
+func IsIndexMediaType(mediaType string) bool {
+	return mediaType == MediaTypeOCIImageIndex || mediaType == MediaTypeDockerManifestList
+}
+
+// PlatformManifest is one child manifest of a multi-arch index.
+type PlatformManifest struct {
+	Digest       string
+	MediaType    string
+	OS           string
+	Architecture string
+	Variant      string
+}
+
+// ModuleName returns the per-platform build-info module name "<baseModule>/<os>-<arch>[/<variant>]".
+func (p PlatformManifest) ModuleName(baseModule string) string {
+	name := baseModule + "/" + p.OS + "-" + p.Architecture
+	if p.Variant != "" {
+		name += "/" + p.Variant
+	}
+	return name
+}
+
+type manifestDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Platform  *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+		Variant      string `json:"variant,omitempty"`
+	} `json:"platform,omitempty"`
+}
+
+type indexManifest struct {
+	MediaType string               `json:"mediaType"`
+	Manifests []manifestDescriptor `json:"manifests"`
+}
+
+// FetchIndex fetches repo/named's manifest from Artifactory and, if it is an OCI index or Docker manifest
+// list, returns its mediaType and per-platform child manifests. isIndex is false for a plain (single-arch)
+// manifest, in which case children is empty.
+func FetchIndex(serverDetails *config.ServerDetails, repo string, named Named) (mediaType string, children []PlatformManifest, isIndex bool, err error) {
+	reference := named.Tag
+	if named.IsDigested() {
+		reference = named.Digest
+	}
+	urlPath := fmt.Sprintf("v2/%s/%s/manifests/%s", repo, named.Repository, reference)
+	status, body, err := doArtifactoryRequest(serverDetails, "GET", urlPath)
+	if err != nil {
+		return "", nil, false, err
+	}
+	if status != 200 {
+		return "", nil, false, statusError("fetching manifest "+urlPath, status, body)
+	}
+	var parsed indexManifest
+	if jsonErr := json.Unmarshal(body, &parsed); jsonErr != nil {
+		return "", nil, false, errorutils.CheckError(jsonErr)
+	}
+	if !IsIndexMediaType(parsed.MediaType) {
+		return parsed.MediaType, nil, false, nil
+	}
+	platforms := make([]PlatformManifest, 0, len(parsed.Manifests))
+	for _, m := range parsed.Manifests {
+		pm := PlatformManifest{Digest: m.Digest, MediaType: m.MediaType}
+		if m.Platform != nil {
+			pm.OS = m.Platform.OS
+			pm.Architecture = m.Platform.Architecture
+			pm.Variant = m.Platform.Variant
+		}
+		platforms = append(platforms, pm)
+	}
+	return parsed.MediaType, platforms, true, nil
+}
+
+// FilterPlatforms restricts all to the "os/arch[/variant]" entries named in allowed. An empty allowed list
+// is a no-op: every platform is kept.
+func FilterPlatforms(all []PlatformManifest, allowed []string) []PlatformManifest {
+	if len(allowed) == 0 {
+		return all
+	}
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, a := range allowed {
+		allowedSet[a] = true
+	}
+	filtered := make([]PlatformManifest, 0, len(all))
+	for _, p := range all {
+		key := p.OS + "/" + p.Architecture
+		if p.Variant != "" {
+			key += "/" + p.Variant
+		}
+		if allowedSet[key] {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
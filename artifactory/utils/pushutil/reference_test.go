@@ -0,0 +1,92 @@
+package pushutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNamed_Tagged(t *testing.T) {
+	named, err := ParseNamed("myimg:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "myimg", named.Repository)
+	assert.Equal(t, "1.0", named.Tag)
+	assert.False(t, named.IsDigested())
+}
+
+func TestParseNamed_Digested(t *testing.T) {
+	named, err := ParseNamed("repo/myimg@sha256:abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, "repo/myimg", named.Repository)
+	assert.Equal(t, "sha256:abcdef", named.Digest)
+	assert.True(t, named.IsDigested())
+}
+
+func TestParseNamed_DefaultsToLatest(t *testing.T) {
+	named, err := ParseNamed("myimg")
+	require.NoError(t, err)
+	assert.Equal(t, "latest", named.Tag)
+}
+
+func TestPlatformManifest_ModuleName(t *testing.T) {
+	p := PlatformManifest{OS: "linux", Architecture: "arm64", Variant: "v8"}
+	assert.Equal(t, "myapp/linux-arm64/v8", p.ModuleName("myapp"))
+
+	p = PlatformManifest{OS: "linux", Architecture: "amd64"}
+	assert.Equal(t, "myapp/linux-amd64", p.ModuleName("myapp"))
+}
+
+func TestFilterPlatforms(t *testing.T) {
+	all := []PlatformManifest{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64", Variant: "v8"},
+	}
+	assert.Equal(t, all, FilterPlatforms(all, nil))
+
+	filtered := FilterPlatforms(all, []string{"linux/arm64/v8"})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "arm64", filtered[0].Architecture)
+}
+
+func TestFetchIndex_PlainManifest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"mediaType":"application/vnd.oci.image.manifest.v1+json"}`))
+	}))
+	defer server.Close()
+
+	named, err := ParseNamed("myimg:1.0")
+	require.NoError(t, err)
+	mediaType, children, isIndex, err := FetchIndex(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", named)
+	require.NoError(t, err)
+	assert.False(t, isIndex)
+	assert.Empty(t, children)
+	assert.Equal(t, "application/vnd.oci.image.manifest.v1+json", mediaType)
+}
+
+func TestFetchIndex_MultiArch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/docker-local/myimg/manifests/1.0", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"mediaType": "application/vnd.oci.image.index.v1+json",
+			"manifests": [
+				{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:aaa", "platform": {"os": "linux", "architecture": "amd64"}},
+				{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:bbb", "platform": {"os": "linux", "architecture": "arm64", "variant": "v8"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	named, err := ParseNamed("myimg:1.0")
+	require.NoError(t, err)
+	mediaType, children, isIndex, err := FetchIndex(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", named)
+	require.NoError(t, err)
+	assert.True(t, isIndex)
+	assert.Equal(t, MediaTypeOCIImageIndex, mediaType)
+	require.Len(t, children, 2)
+	assert.Equal(t, "amd64", children[0].Architecture)
+	assert.Equal(t, "v8", children[1].Variant)
+}
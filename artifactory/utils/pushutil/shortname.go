@@ -0,0 +1,215 @@
+package pushutil
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// ShortNameMode controls how PushCommand resolves an unqualified image reference (e.g. "myimg:1.0") before
+// handing it to the native docker client.
+type ShortNameMode string
+
+const (
+	// ShortNameModePermissive resolves unqualified names using the alias table, falling back to the
+	// server's default registry when no alias matches.
+	ShortNameModePermissive ShortNameMode = "permissive"
+	// ShortNameModeEnforcing rejects unqualified names that don't match a configured alias.
+	ShortNameModeEnforcing ShortNameMode = "enforcing"
+	// ShortNameModeDisabled preserves the pre-existing behavior: image references are passed through unchanged.
+	ShortNameModeDisabled ShortNameMode = "disabled"
+)
+
+const shortNameAliasesFileName = "short-name-aliases.conf"
+
+// ShortNameResolver rewrites unqualified image references into fully-qualified ones.
+type ShortNameResolver struct {
+	mode          ShortNameMode
+	aliases       map[string]string
+	serverDetails *config.ServerDetails
+}
+
+// NewShortNameResolver builds a resolver for mode. If aliases is nil, the alias table is loaded from
+// ~/.jfrog/short-name-aliases.conf.
+func NewShortNameResolver(mode ShortNameMode, serverDetails *config.ServerDetails, aliases map[string]string) (*ShortNameResolver, error) {
+	if aliases == nil {
+		loaded, err := LoadShortNameAliases()
+		if err != nil {
+			return nil, err
+		}
+		aliases = loaded
+	}
+	return &ShortNameResolver{mode: mode, aliases: aliases, serverDetails: serverDetails}, nil
+}
+
+// Resolve rewrites imageRef according to the resolver's mode, preserving the user-supplied tag or digest.
+// Fully-qualified references (those whose repository component already names a registry host) are returned
+// unchanged regardless of mode.
+func (r *ShortNameResolver) Resolve(imageRef string) (string, error) {
+	repoComponent, suffix, isDigest := splitRefComponents(imageRef)
+	if isFullyQualified(repoComponent) {
+		return imageRef, nil
+	}
+	if r.mode != ShortNameModePermissive && r.mode != ShortNameModeEnforcing {
+		// ShortNameModeDisabled, or unset: no alias is applied either.
+		return imageRef, nil
+	}
+	if fqRepo, ok := longestAliasMatch(r.aliases, repoComponent); ok {
+		return joinRefComponents(fqRepo, suffix, isDigest), nil
+	}
+	switch r.mode {
+	case ShortNameModeEnforcing:
+		return "", errorutils.CheckError(fmt.Errorf("image reference %q is not fully qualified and matches no configured short-name alias", imageRef))
+	case ShortNameModePermissive:
+		defaultRegistry := defaultRegistryHost(r.serverDetails)
+		if defaultRegistry == "" {
+			return imageRef, nil
+		}
+		return joinRefComponents(defaultRegistry+"/"+repoComponent, suffix, isDigest), nil
+	default: // ShortNameModeDisabled, or unset
+		return imageRef, nil
+	}
+}
+
+// isFullyQualified mirrors Docker's own heuristic: the first path component is a registry host if it
+// contains a "." or ":" (a domain or a host:port), or is literally "localhost".
+func isFullyQualified(repoComponent string) bool {
+	firstComponent := repoComponent
+	if idx := strings.Index(repoComponent, "/"); idx >= 0 {
+		firstComponent = repoComponent[:idx]
+	} else {
+		// A single-component reference with no registry host (e.g. "myimg") is never fully qualified.
+		return false
+	}
+	return firstComponent == "localhost" || strings.ContainsAny(firstComponent, ".:")
+}
+
+func splitRefComponents(imageRef string) (repoComponent, suffix string, isDigest bool) {
+	if idx := strings.Index(imageRef, "@"); idx >= 0 {
+		return imageRef[:idx], imageRef[idx+1:], true
+	}
+	if idx := strings.LastIndex(imageRef, ":"); idx >= 0 && !strings.Contains(imageRef[idx:], "/") {
+		return imageRef[:idx], imageRef[idx+1:], false
+	}
+	return imageRef, "latest", false
+}
+
+func joinRefComponents(repo, suffix string, isDigest bool) string {
+	if isDigest {
+		return repo + "@" + suffix
+	}
+	return repo + ":" + suffix
+}
+
+// longestAliasMatch finds the alias whose key is the longest prefix of repoComponent's repository path.
+func longestAliasMatch(aliases map[string]string, repoComponent string) (string, bool) {
+	best := ""
+	bestTarget := ""
+	for alias, target := range aliases {
+		if repoComponent == alias || strings.HasPrefix(repoComponent, alias+"/") {
+			if len(alias) > len(best) {
+				best = alias
+				bestTarget = target
+			}
+		}
+	}
+	if best == "" {
+		return "", false
+	}
+	return bestTarget + strings.TrimPrefix(repoComponent, best), true
+}
+
+// defaultRegistryHost derives the pushable registry host[:port] from serverDetails.ArtifactoryUrl. The URL
+// itself is Artifactory's REST API endpoint (e.g. "https://my-artifactory.example.com/artifactory/"), whose
+// "/artifactory" context path isn't part of the docker registry hostname images are pushed to, so it's
+// stripped along with everything else after the host.
+func defaultRegistryHost(serverDetails *config.ServerDetails) string {
+	if serverDetails == nil || serverDetails.ArtifactoryUrl == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(serverDetails.ArtifactoryUrl, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host = strings.TrimSuffix(host, "/")
+	if idx := strings.Index(host, "/"); idx >= 0 {
+		host = host[:idx]
+	}
+	return host
+}
+
+// ShortNameModeForServer returns the short-name mode configured for serverDetails via
+// "mode.<server-id>=<mode>" lines in ~/.jfrog/short-name-aliases.conf, or ShortNameModeDisabled if none is
+// set. config.ServerDetails itself carries no short-name-mode field, so the mode is keyed by server ID in
+// the same local config file that stores the alias table.
+func ShortNameModeForServer(serverDetails *config.ServerDetails) ShortNameMode {
+	if serverDetails == nil || serverDetails.ServerId == "" {
+		return ShortNameModeDisabled
+	}
+	filePath, err := shortNameAliasesFilePath()
+	if err != nil {
+		return ShortNameModeDisabled
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return ShortNameModeDisabled
+	}
+	defer file.Close()
+
+	prefix := "mode." + serverDetails.ServerId + "="
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, prefix) {
+			return ShortNameMode(strings.TrimSpace(strings.TrimPrefix(line, prefix)))
+		}
+	}
+	return ShortNameModeDisabled
+}
+
+// LoadShortNameAliases reads the shortname -> fully-qualified-ref alias table from
+// ~/.jfrog/short-name-aliases.conf. Lines are "shortname=fully-qualified-ref"; blank lines and lines
+// starting with "#" are ignored. A missing file is not an error: it resolves to an empty alias table.
+func LoadShortNameAliases() (map[string]string, error) {
+	filePath, err := shortNameAliasesFilePath()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+
+	aliases := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		aliases[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return aliases, nil
+}
+
+func shortNameAliasesFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".jfrog", shortNameAliasesFileName), nil
+}
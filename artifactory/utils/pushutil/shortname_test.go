@@ -0,0 +1,124 @@
+package pushutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withFakeHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // Windows fallback used by os.UserHomeDir.
+	return home
+}
+
+func writeAliasesConf(t *testing.T, home, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".jfrog"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".jfrog", shortNameAliasesFileName), []byte(content), 0o600))
+}
+
+func TestIsFullyQualified(t *testing.T) {
+	assert.True(t, isFullyQualified("my-artifactory.example.com/docker-local/myimg"))
+	assert.True(t, isFullyQualified("localhost/myimg"))
+	assert.True(t, isFullyQualified("my-registry:5000/myimg"))
+	assert.False(t, isFullyQualified("myimg"))
+	assert.False(t, isFullyQualified("docker-local/myimg"))
+}
+
+func TestShortNameResolver_DisabledPassesThrough(t *testing.T) {
+	resolver, err := NewShortNameResolver(ShortNameModeDisabled, &config.ServerDetails{}, map[string]string{})
+	require.NoError(t, err)
+	resolved, err := resolver.Resolve("myimg:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "myimg:1.0", resolved)
+}
+
+func TestShortNameResolver_EnforcingRejectsUnmatched(t *testing.T) {
+	resolver, err := NewShortNameResolver(ShortNameModeEnforcing, &config.ServerDetails{}, map[string]string{})
+	require.NoError(t, err)
+	_, err = resolver.Resolve("myimg:1.0")
+	assert.Error(t, err)
+}
+
+func TestShortNameResolver_EnforcingAcceptsAlias(t *testing.T) {
+	aliases := map[string]string{"docker-local": "my-artifactory.example.com/docker-local"}
+	resolver, err := NewShortNameResolver(ShortNameModeEnforcing, &config.ServerDetails{}, aliases)
+	require.NoError(t, err)
+	resolved, err := resolver.Resolve("docker-local/myimg:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "my-artifactory.example.com/docker-local/myimg:1.0", resolved)
+}
+
+func TestShortNameResolver_PermissiveFallsBackToDefaultRegistry(t *testing.T) {
+	resolver, err := NewShortNameResolver(ShortNameModePermissive, &config.ServerDetails{ArtifactoryUrl: "https://my-artifactory.example.com/artifactory/"}, map[string]string{})
+	require.NoError(t, err)
+	resolved, err := resolver.Resolve("myimg:1.0")
+	require.NoError(t, err)
+	// The resolved reference must be a usable registry reference: just host[:port]/repo, with no REST API
+	// context path ("/artifactory") in it, since that path isn't part of the docker registry hostname.
+	assert.Equal(t, "my-artifactory.example.com/myimg:1.0", resolved)
+}
+
+func TestDefaultRegistryHost_StripsArtifactoryContextPath(t *testing.T) {
+	host := defaultRegistryHost(&config.ServerDetails{ArtifactoryUrl: "https://my-artifactory.example.com/artifactory/"})
+	assert.Equal(t, "my-artifactory.example.com", host)
+}
+
+func TestDefaultRegistryHost_PreservesPort(t *testing.T) {
+	host := defaultRegistryHost(&config.ServerDetails{ArtifactoryUrl: "http://my-artifactory.example.com:8081/artifactory"})
+	assert.Equal(t, "my-artifactory.example.com:8081", host)
+}
+
+func TestShortNameResolver_PreservesDigest(t *testing.T) {
+	aliases := map[string]string{"docker-local": "my-artifactory.example.com/docker-local"}
+	resolver, err := NewShortNameResolver(ShortNameModeEnforcing, &config.ServerDetails{}, aliases)
+	require.NoError(t, err)
+	resolved, err := resolver.Resolve("docker-local/myimg@sha256:abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, "my-artifactory.example.com/docker-local/myimg@sha256:abcdef", resolved)
+}
+
+func TestLongestAliasMatch(t *testing.T) {
+	aliases := map[string]string{
+		"docker-local":        "registry.example.com/docker-local",
+		"docker-local/team-a": "registry.example.com/team-a-local",
+	}
+	target, ok := longestAliasMatch(aliases, "docker-local/team-a/myimg")
+	require.True(t, ok)
+	assert.Equal(t, "registry.example.com/team-a-local/myimg", target)
+}
+
+func TestLoadShortNameAliases(t *testing.T) {
+	home := withFakeHome(t)
+	writeAliasesConf(t, home, "# comment\ndocker-local=registry.example.com/docker-local\n\nbad-line\n")
+	aliases, err := LoadShortNameAliases()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"docker-local": "registry.example.com/docker-local"}, aliases)
+}
+
+func TestLoadShortNameAliases_MissingFile(t *testing.T) {
+	withFakeHome(t)
+	aliases, err := LoadShortNameAliases()
+	require.NoError(t, err)
+	assert.Empty(t, aliases)
+}
+
+func TestShortNameModeForServer(t *testing.T) {
+	home := withFakeHome(t)
+	writeAliasesConf(t, home, "mode.my-server=enforcing\n")
+	mode := ShortNameModeForServer(&config.ServerDetails{ServerId: "my-server"})
+	assert.Equal(t, ShortNameModeEnforcing, mode)
+}
+
+func TestShortNameModeForServer_DefaultsToDisabled(t *testing.T) {
+	withFakeHome(t)
+	mode := ShortNameModeForServer(&config.ServerDetails{ServerId: "unconfigured-server"})
+	assert.Equal(t, ShortNameModeDisabled, mode)
+}
@@ -0,0 +1,263 @@
+package pushutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// ImageSigner produces a signature payload over a manifest's canonical bytes.
+type ImageSigner interface {
+	// Name identifies the signer, used in log output and as the signature artifact's producer.
+	Name() string
+	// Sign returns the signature payload for the given canonical manifest bytes.
+	Sign(manifestBytes []byte) ([]byte, error)
+}
+
+// CosignSigner signs manifests with a cosign-compatible EC private key (https://docs.sigstore.dev/cosign).
+// This is the default signer; callers holding the legacy Docker Content Trust RSA key format use LibtrustSigner.
+type CosignSigner struct {
+	KeyBytes []byte
+}
+
+func (s *CosignSigner) Name() string {
+	return "cosign"
+}
+
+func (s *CosignSigner) Sign(manifestBytes []byte) ([]byte, error) {
+	if len(s.KeyBytes) == 0 {
+		return nil, errorutils.CheckError(fmt.Errorf("cosign signer requires a signing key"))
+	}
+	return signECDSA(s.KeyBytes, manifestBytes)
+}
+
+// LibtrustSigner signs manifests with the legacy Docker Content Trust (libtrust) RSA key format.
+type LibtrustSigner struct {
+	KeyBytes []byte
+}
+
+func (s *LibtrustSigner) Name() string {
+	return "libtrust"
+}
+
+func (s *LibtrustSigner) Sign(manifestBytes []byte) ([]byte, error) {
+	if len(s.KeyBytes) == 0 {
+		return nil, errorutils.CheckError(fmt.Errorf("libtrust signer requires a signing key"))
+	}
+	return signRSA(s.KeyBytes, manifestBytes)
+}
+
+// signECDSA signs the SHA-256 digest of data with the EC private key PEM-encoded in keyBytes, as cosign does.
+func signECDSA(keyBytes, data []byte) ([]byte, error) {
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, errorutils.CheckError(fmt.Errorf("signing key is not a PEM-encoded EC private key"))
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("parsing EC signing key: %w", err))
+	}
+	digest := sha256.Sum256(data)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("signing manifest: %w", err))
+	}
+	return signature, nil
+}
+
+// signRSA signs the SHA-256 digest of data with the RSA private key PEM-encoded in keyBytes, as legacy
+// libtrust-based Docker Content Trust does.
+func signRSA(keyBytes, data []byte) ([]byte, error) {
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, errorutils.CheckError(fmt.Errorf("signing key is not a PEM-encoded RSA private key"))
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("parsing RSA signing key: %w", err))
+	}
+	digest := sha256.Sum256(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("signing manifest: %w", err))
+	}
+	return signature, nil
+}
+
+// NewImageSigner resolves signingKeyRef (a file path, an "env://VAR" reference, or an Artifactory-stored key
+// path) and returns the signer matching the resolved key's PEM block type: an EC private key selects
+// CosignSigner (cosign's own default key type), an RSA private key selects the legacy LibtrustSigner.
+func NewImageSigner(serverDetails *config.ServerDetails, signingKeyRef string) (ImageSigner, error) {
+	keyBytes, err := ResolveSigningKeyRef(serverDetails, signingKeyRef)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, errorutils.CheckError(fmt.Errorf("signing key %q is not a PEM-encoded private key", signingKeyRef))
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return &CosignSigner{KeyBytes: keyBytes}, nil
+	case "RSA PRIVATE KEY":
+		return &LibtrustSigner{KeyBytes: keyBytes}, nil
+	default:
+		return nil, errorutils.CheckError(fmt.Errorf("unsupported signing key type %q in %q: expected an EC (cosign) or RSA (libtrust) private key", block.Type, signingKeyRef))
+	}
+}
+
+// ResolveSigningKeyRef loads the signing key referenced by ref, which may be:
+//   - a local file path
+//   - "env://VAR", read from the named environment variable
+//   - an Artifactory repository path, downloaded from serverDetails
+func ResolveSigningKeyRef(serverDetails *config.ServerDetails, ref string) ([]byte, error) {
+	if ref == "" {
+		return nil, errorutils.CheckError(fmt.Errorf("signing key reference must not be empty"))
+	}
+	if strings.HasPrefix(ref, "env://") {
+		varName := strings.TrimPrefix(ref, "env://")
+		value := os.Getenv(varName)
+		if value == "" {
+			return nil, errorutils.CheckError(fmt.Errorf("environment variable %q referenced by signing key is not set", varName))
+		}
+		return []byte(value), nil
+	}
+	if info, statErr := os.Stat(ref); statErr == nil && !info.IsDir() {
+		return os.ReadFile(ref)
+	}
+	status, body, err := doArtifactoryRequest(serverDetails, http.MethodGet, ref)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError("downloading signing key "+ref, status, body)
+	}
+	return body, nil
+}
+
+// GetManifestDigest resolves the manifest digest for repo/imageRef. It issues a HEAD request for the
+// manifest and falls back to the locally-computed image SHA when the registry doesn't echo a digest header.
+func GetManifestDigest(serverDetails *config.ServerDetails, repo, imageRef, localImageSha256 string) (string, error) {
+	name, tag := splitRepository(imageRef)
+	urlPath := fmt.Sprintf("v2/%s/%s/manifests/%s", repo, name, tag)
+	reqUrl := strings.TrimSuffix(serverDetails.ArtifactoryUrl, "/") + "/" + urlPath
+	req, err := http.NewRequest(http.MethodHead, reqUrl, nil)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json")
+	setAuth(req, serverDetails)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// Registries that don't support HEAD on manifests, or are unreachable from the invoking
+		// machine, fall back to the digest the container manager already computed locally.
+		return "sha256:" + localImageSha256, nil
+	}
+	defer resp.Body.Close()
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	return "sha256:" + localImageSha256, nil
+}
+
+// fetchManifestBytes downloads repo/imageRef's manifest, returning its canonical bytes as served by the
+// registry, i.e. the bytes a signature must cover to be verifiable against the pushed manifest's digest.
+func fetchManifestBytes(serverDetails *config.ServerDetails, repo, imageRef string) ([]byte, error) {
+	name, tagOrDigest := splitRepository(imageRef)
+	urlPath := fmt.Sprintf("v2/%s/%s/manifests/%s", repo, name, tagOrDigest)
+	status, body, err := doArtifactoryRequest(serverDetails, http.MethodGet, urlPath)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError("fetching manifest "+urlPath, status, body)
+	}
+	return body, nil
+}
+
+// SignAndUploadManifest signs the canonical bytes of repo/imageRef's manifest with signer and uploads the
+// resulting signature artifact to repo, preferring the OCI 1.1 referrers API and falling back to a sibling
+// "<digest>.sig" tag when the registry doesn't answer the referrers API.
+func SignAndUploadManifest(signer ImageSigner, serverDetails *config.ServerDetails, repo, imageRef, manifestDigest string) ([]clientutils.FileTransferDetails, error) {
+	name, _ := splitRepository(imageRef)
+	manifestBytes, err := fetchManifestBytes(serverDetails, repo, imageRef)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.Sign(manifestBytes)
+	if err != nil {
+		return nil, err
+	}
+	sigDigest := fmt.Sprintf("%x", sha256.Sum256(signature))
+	targetPath, contentType := signatureTargetPath(serverDetails, repo, name, manifestDigest)
+	status, body, err := uploadBytes(serverDetails, targetPath, contentType, signature)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 300 {
+		return nil, statusError("uploading signature to "+targetPath, status, body)
+	}
+	return []clientutils.FileTransferDetails{{
+		TargetPath: targetPath,
+		RtUrl:      serverDetails.ArtifactoryUrl,
+		Sha256:     sigDigest,
+	}}, nil
+}
+
+// signatureTargetPath picks the OCI 1.1 referrer artifact path when the registry answers the referrers API,
+// detected at runtime with a single probe request, falling back to the legacy sibling "<digest>.sig" tag
+// otherwise. The two destinations use different content types: a referrer artifact is typed as a cosign
+// signature artifact, while the legacy sibling tag is an opaque blob.
+func signatureTargetPath(serverDetails *config.ServerDetails, repo, name, manifestDigest string) (targetPath, contentType string) {
+	sanitizedDigest := strings.ReplaceAll(manifestDigest, ":", "-")
+	if supportsReferrers(serverDetails, repo, name, manifestDigest) {
+		return path.Join(repo, name, "referrers", sanitizedDigest), "application/vnd.dev.cosign.artifact.sig.v1+json"
+	}
+	return path.Join(repo, name, sanitizedDigest+".sig"), "application/octet-stream"
+}
+
+// supportsReferrers probes repo's OCI 1.1 referrers API (GET .../referrers/<digest>) for name/manifestDigest,
+// reporting whether the registry answers it. A non-200 response, or no response at all, means no.
+func supportsReferrers(serverDetails *config.ServerDetails, repo, name, manifestDigest string) bool {
+	status, _, err := doArtifactoryRequest(serverDetails, http.MethodGet, fmt.Sprintf("v2/%s/%s/referrers/%s", repo, name, manifestDigest))
+	return err == nil && status == http.StatusOK
+}
+
+func uploadBytes(serverDetails *config.ServerDetails, targetPath, contentType string, data []byte) (int, []byte, error) {
+	reqUrl := strings.TrimSuffix(serverDetails.ArtifactoryUrl, "/") + "/" + targetPath
+	req, err := http.NewRequest(http.MethodPut, reqUrl, strings.NewReader(string(data)))
+	if err != nil {
+		return 0, nil, errorutils.CheckError(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	setAuth(req, serverDetails)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, errorutils.CheckError(err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil, nil
+}
+
+func splitRepository(imageRef string) (name, tagOrDigest string) {
+	if idx := strings.Index(imageRef, "@"); idx >= 0 {
+		return imageRef[:idx], imageRef[idx+1:]
+	}
+	if idx := strings.LastIndex(imageRef, ":"); idx >= 0 && !strings.Contains(imageRef[idx:], "/") {
+		return imageRef[:idx], imageRef[idx+1:]
+	}
+	return imageRef, "latest"
+}
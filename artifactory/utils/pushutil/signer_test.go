@@ -0,0 +1,170 @@
+package pushutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Sum(t *testing.T, data []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(data)
+	return digest[:]
+}
+
+func generateECKeyPEM(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func generateRSAKeyPEM(t *testing.T) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der := x509.MarshalPKCS1PrivateKey(key)
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der})
+}
+
+func TestResolveSigningKeyRef_EnvVar(t *testing.T) {
+	t.Setenv("TEST_SIGNING_KEY", "super-secret-key")
+	keyBytes, err := ResolveSigningKeyRef(&config.ServerDetails{}, "env://TEST_SIGNING_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, "super-secret-key", string(keyBytes))
+}
+
+func TestResolveSigningKeyRef_EnvVarMissing(t *testing.T) {
+	_, err := ResolveSigningKeyRef(&config.ServerDetails{}, "env://DOES_NOT_EXIST_SIGNING_KEY")
+	assert.Error(t, err)
+}
+
+func TestResolveSigningKeyRef_FilePath(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, []byte("file-key-bytes"), 0o600))
+	keyBytes, err := ResolveSigningKeyRef(&config.ServerDetails{}, keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, "file-key-bytes", string(keyBytes))
+}
+
+func TestResolveSigningKeyRef_ArtifactoryStored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/security-keys/my-key.pem", r.URL.Path)
+		_, _ = w.Write([]byte("artifactory-key-bytes"))
+	}))
+	defer server.Close()
+
+	keyBytes, err := ResolveSigningKeyRef(&config.ServerDetails{ArtifactoryUrl: server.URL}, "security-keys/my-key.pem")
+	require.NoError(t, err)
+	assert.Equal(t, "artifactory-key-bytes", string(keyBytes))
+}
+
+func TestCosignSigner_SignRequiresKey(t *testing.T) {
+	signer := &CosignSigner{}
+	_, err := signer.Sign([]byte("manifest-bytes"))
+	assert.Error(t, err)
+}
+
+func TestCosignSigner_Sign(t *testing.T) {
+	keyPEM := generateECKeyPEM(t)
+	signer := &CosignSigner{KeyBytes: keyPEM}
+	sig, err := signer.Sign([]byte(`{"schemaVersion":2}`))
+	require.NoError(t, err)
+	assert.NotEmpty(t, sig)
+	assert.Equal(t, "cosign", signer.Name())
+
+	block, _ := pem.Decode(keyPEM)
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	require.NoError(t, err)
+	digest := sha256Sum(t, []byte(`{"schemaVersion":2}`))
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest, sig))
+}
+
+func TestLibtrustSigner_SignRequiresKey(t *testing.T) {
+	signer := &LibtrustSigner{}
+	_, err := signer.Sign([]byte("manifest-bytes"))
+	assert.Error(t, err)
+}
+
+func TestLibtrustSigner_Sign(t *testing.T) {
+	keyPEM := generateRSAKeyPEM(t)
+	signer := &LibtrustSigner{KeyBytes: keyPEM}
+	sig, err := signer.Sign([]byte(`{"schemaVersion":2}`))
+	require.NoError(t, err)
+	assert.NotEmpty(t, sig)
+	assert.Equal(t, "libtrust", signer.Name())
+}
+
+func TestNewImageSigner_SelectsCosignForECKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, generateECKeyPEM(t), 0o600))
+
+	signer, err := NewImageSigner(&config.ServerDetails{}, keyFile)
+	require.NoError(t, err)
+	assert.IsType(t, &CosignSigner{}, signer)
+}
+
+func TestNewImageSigner_SelectsLibtrustForRSAKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, generateRSAKeyPEM(t), 0o600))
+
+	signer, err := NewImageSigner(&config.ServerDetails{}, keyFile)
+	require.NoError(t, err)
+	assert.IsType(t, &LibtrustSigner{}, signer)
+}
+
+func TestNewImageSigner_RejectsUnsupportedKeyType(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("pkcs8")}), 0o600))
+
+	_, err := NewImageSigner(&config.ServerDetails{}, keyFile)
+	assert.Error(t, err)
+}
+
+func TestSignatureTargetPath_FallsBackToSiblingTagWhenReferrersUnsupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	targetPath, contentType := signatureTargetPath(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", "myimg", "sha256:abcdef")
+	assert.Equal(t, "docker-local/myimg/sha256-abcdef.sig", targetPath)
+	assert.Equal(t, "application/octet-stream", contentType)
+}
+
+func TestSignatureTargetPath_UsesReferrersApiWhenSupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/docker-local/myimg/referrers/sha256:abcdef", r.URL.Path)
+		_, _ = w.Write([]byte(`{"manifests":[]}`))
+	}))
+	defer server.Close()
+
+	targetPath, contentType := signatureTargetPath(&config.ServerDetails{ArtifactoryUrl: server.URL}, "docker-local", "myimg", "sha256:abcdef")
+	assert.Equal(t, "docker-local/myimg/referrers/sha256-abcdef", targetPath)
+	assert.Equal(t, "application/vnd.dev.cosign.artifact.sig.v1+json", contentType)
+}
+
+func TestSplitRepository(t *testing.T) {
+	name, tag := splitRepository("myimg:1.0")
+	assert.Equal(t, "myimg", name)
+	assert.Equal(t, "1.0", tag)
+
+	name, digest := splitRepository("myimg@sha256:abc123")
+	assert.Equal(t, "myimg", name)
+	assert.Equal(t, "sha256:abc123", digest)
+}